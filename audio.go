@@ -1,3 +1,5 @@
+//go:build cgo
+
 package audio
 
 import (
@@ -7,6 +9,7 @@ import (
 	"time"
 
 	"github.com/MarkKremer/microphone/v2"
+	"github.com/codewandler/audio-go/audio/resample"
 	"github.com/gopxl/beep/v2"
 	"github.com/gopxl/beep/v2/speaker"
 )
@@ -14,18 +17,10 @@ import (
 const (
 	defaultPlayLatency    = 200 * time.Millisecond // defaultPlayLatency speaker buffer = 200 ms
 	defaultCaptureLatency = 200 * time.Millisecond // defaultPlayLatency capture buffer = 200 ms
-	defaultSampleRate     = 48_000                 // defaultSampleRate is the default sample rate
 	bytesPerSample        = 2                      // 16-bit mono PCM
 	captureFrames         = 1024                   // mic pull size
 )
 
-type Config struct {
-	PlaySampleRate    int
-	PlayLatency       time.Duration
-	CaptureSampleRate int
-	CaptureLatency    time.Duration
-}
-
 // NewAudioIO returns an io.ReadWriter that speaks 16-bit MONO PCM.
 // ctx / framesPerBuffer are ignored for API compatibility.
 func NewAudioIO(
@@ -76,6 +71,22 @@ func NewAudioIO(
 	}
 	a.readCond = sync.NewCond(&a.readMu)
 
+	captureDeviceFormat := Format{SampleRate: config.CaptureSampleRate, Channels: 1, Encoding: EncodingPCM16}
+	if captureFormat := config.CaptureFormat.orDefault(captureDeviceFormat); captureFormat != captureDeviceFormat {
+		a.captureResampler = resample.New(
+			resample.Format{SampleRate: captureDeviceFormat.SampleRate, Channels: captureDeviceFormat.Channels},
+			resample.Format{SampleRate: captureFormat.SampleRate, Channels: captureFormat.Channels},
+			config.ResampleMethod, config.ResampleTaps)
+	}
+
+	playDeviceFormat := Format{SampleRate: config.PlaySampleRate, Channels: 1, Encoding: EncodingPCM16}
+	if playFormat := config.PlayFormat.orDefault(playDeviceFormat); playFormat != playDeviceFormat {
+		a.playResampler = resample.New(
+			resample.Format{SampleRate: playFormat.SampleRate, Channels: playFormat.Channels},
+			resample.Format{SampleRate: playDeviceFormat.SampleRate, Channels: playDeviceFormat.Channels},
+			config.ResampleMethod, config.ResampleTaps)
+	}
+
 	go a.captureLoop()
 	return a, nil
 }
@@ -89,6 +100,9 @@ type AudioIO struct {
 	readMu       sync.Mutex
 	readBuf      []byte
 	readCond     *sync.Cond // 🚨 new condition variable
+
+	captureResampler *resample.Resampler // capture device format -> caller CaptureFormat
+	playResampler    *resample.Resampler // caller PlayFormat -> play device format
 }
 
 // --------------------------- io.Reader --------------------------------------
@@ -108,7 +122,11 @@ func (a *AudioIO) Read(p []byte) (int, error) {
 
 // --------------------------- io.Writer --------------------------------------
 
-func (a *AudioIO) Write(b []byte) (int, error) {
+func (a *AudioIO) Write(p []byte) (int, error) {
+	b := p
+	if a.playResampler != nil {
+		b = int16ToBytes(a.playResampler.Process(bytesToInt16(p)))
+	}
 	if len(b)%bytesPerSample != 0 {
 		return 0, errors.New("audio: Write expects 16-bit mono PCM")
 	}
@@ -118,7 +136,7 @@ func (a *AudioIO) Write(b []byte) (int, error) {
 		f := float64(v) / 32768.0    // range -1..1
 		a.playCh <- [2]float64{f, f} // duplicate to stereo
 	}
-	return len(b), nil
+	return len(p), nil
 }
 
 // ---------------------------------------------------------------------------
@@ -133,6 +151,9 @@ func (a *AudioIO) captureLoop() {
 		}
 
 		mono := stereoSamplesToPCM16Mono(frames[:n])
+		if a.captureResampler != nil {
+			mono = int16ToBytes(a.captureResampler.Process(bytesToInt16(mono)))
+		}
 
 		a.readMu.Lock()
 		a.readBuf = append(a.readBuf, mono...)
@@ -0,0 +1,67 @@
+// Package codec streams PCM16 audio into and out of common container/codec
+// formats, so callers don't have to hand-roll WAV headers or shell out to
+// ffmpeg just to save a recording or play a file.
+package codec
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format describes the PCM layout an Encoder accepts or a Decoder produces.
+type Format struct {
+	SampleRate int
+	Channels   int
+}
+
+type encoderFactory func(w io.Writer, format Format) (io.WriteCloser, error)
+type decoderFactory func(r io.Reader) (io.Reader, Format, error)
+
+var (
+	encoders = map[string]encoderFactory{}
+	decoders = map[string]decoderFactory{}
+)
+
+// registerCodec makes an encoder and/or decoder available under name to
+// NewEncoder/NewDecoder. Either factory may be nil if the codec only
+// supports one direction.
+func registerCodec(name string, enc encoderFactory, dec decoderFactory) {
+	if enc != nil {
+		encoders[name] = enc
+	}
+	if dec != nil {
+		decoders[name] = dec
+	}
+}
+
+// NewEncoder returns a WriteCloser that accepts 16-bit PCM at format and
+// writes it to w encoded as codec. Encoders are registered for "wav" and,
+// in cgo builds, "opus"; "mp3" and "flac" are decode-only and return an
+// error here. Close must be called to flush any trailing codec state (e.g.
+// a WAV header).
+func NewEncoder(w io.Writer, format Format, codec string) (io.WriteCloser, error) {
+	factory, ok := encoders[codec]
+	if !ok {
+		return nil, fmt.Errorf("audio/codec: no encoder registered for %q (available: %v)", codec, codecNames(encoders))
+	}
+	return factory(w, format)
+}
+
+// NewDecoder returns a Reader yielding 16-bit PCM decoded from r, which must
+// contain a codec-encoded stream, along with the Format the decoder
+// determined from the stream itself.
+func NewDecoder(r io.Reader, codec string) (io.Reader, Format, error) {
+	factory, ok := decoders[codec]
+	if !ok {
+		return nil, Format{}, fmt.Errorf("audio/codec: no decoder registered for %q (available: %v)", codec, codecNames(decoders))
+	}
+	return factory(r)
+}
+
+func codecNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,64 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/mewkiz/flac"
+)
+
+func init() {
+	// flac's Encoder API is explicitly documented upstream as experimental
+	// and requires hand-building frame/subframe structures, so only
+	// decoding is wired up here for now.
+	registerCodec("flac", nil, newFLACDecoder)
+}
+
+// newFLACDecoder decodes FLAC to 16-bit little-endian PCM. Only 16-bit
+// source streams are supported; anything else is rejected rather than
+// silently truncated.
+func newFLACDecoder(r io.Reader) (io.Reader, Format, error) {
+	stream, err := flac.New(r)
+	if err != nil {
+		return nil, Format{}, fmt.Errorf("audio/codec: flac: %w", err)
+	}
+	if stream.Info.BitsPerSample != bitsPerSample {
+		return nil, Format{}, fmt.Errorf("audio/codec: flac: unsupported bit depth %d (only 16-bit PCM is supported)", stream.Info.BitsPerSample)
+	}
+	format := Format{SampleRate: int(stream.Info.SampleRate), Channels: int(stream.Info.NChannels)}
+	return &flacDecoder{stream: stream, channels: int(stream.Info.NChannels)}, format, nil
+}
+
+type flacDecoder struct {
+	stream   *flac.Stream
+	channels int
+	pending  []byte
+}
+
+func (d *flacDecoder) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		frame, err := d.stream.ParseNext()
+		if err != nil {
+			return 0, err
+		}
+		nSamples := int(frame.BlockSize)
+		pcm := make([]byte, 0, nSamples*d.channels*2)
+		for i := 0; i < nSamples; i++ {
+			for c := 0; c < d.channels && c < len(frame.Subframes); c++ {
+				var sample int16
+				if i < len(frame.Subframes[c].Samples) {
+					sample = int16(frame.Subframes[c].Samples[i])
+				}
+				var b [2]byte
+				binary.LittleEndian.PutUint16(b[:], uint16(sample))
+				pcm = append(pcm, b[0], b[1])
+			}
+		}
+		d.pending = pcm
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
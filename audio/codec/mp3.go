@@ -0,0 +1,25 @@
+package codec
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+func init() {
+	// go-mp3 is decode-only (pure Go); there's no pure-Go MP3 encoder this
+	// module can depend on without pulling in cgo bindings to libmp3lame, so
+	// encoding is intentionally left unregistered for now.
+	registerCodec("mp3", nil, newMP3Decoder)
+}
+
+// newMP3Decoder decodes MP3 to 16-bit little-endian PCM. go-mp3 always
+// produces stereo output, even for mono source material.
+func newMP3Decoder(r io.Reader) (io.Reader, Format, error) {
+	dec, err := mp3.NewDecoder(r)
+	if err != nil {
+		return nil, Format{}, fmt.Errorf("audio/codec: mp3: %w", err)
+	}
+	return dec, Format{SampleRate: dec.SampleRate(), Channels: 2}, nil
+}
@@ -0,0 +1,146 @@
+//go:build cgo
+
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/hraban/opus"
+)
+
+func init() {
+	// Opus packets carry no sample-rate/channel-count header of their own,
+	// unlike the other codecs here, so there's no factory that can produce a
+	// decoder from a Reader alone; see NewOpusDecoder.
+	registerCodec("opus", newOpusEncoder, nil)
+}
+
+// opusFrameMillis is the duration of audio the encoder packs into each Opus
+// packet, the 20ms libopus recommends for VoIP-grade audio.
+const opusFrameMillis = 20
+
+// Opus packets are variable-length and carry no resync marker of their own,
+// so the stream is framed as a sequence of {uint32 big-endian length,
+// payload} records.
+
+type opusEncoder struct {
+	w       io.Writer
+	enc     *opus.Encoder
+	format  Format
+	samples []int16
+	cursor  int
+}
+
+func newOpusEncoder(w io.Writer, format Format) (io.WriteCloser, error) {
+	if format.SampleRate <= 0 || format.Channels <= 0 {
+		return nil, fmt.Errorf("audio/codec: opus encoder requires a positive SampleRate and Channels")
+	}
+	enc, err := opus.NewEncoder(format.SampleRate, format.Channels, opus.AppAudio)
+	if err != nil {
+		return nil, fmt.Errorf("audio/codec: opus: %w", err)
+	}
+	frameSamples := format.SampleRate * opusFrameMillis / 1000 * format.Channels
+	return &opusEncoder{w: w, enc: enc, format: format, samples: make([]int16, frameSamples)}, nil
+}
+
+func (e *opusEncoder) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) >= 2 {
+		e.samples[e.cursor] = int16(binary.LittleEndian.Uint16(p))
+		e.cursor++
+		p = p[2:]
+		written += 2
+		if e.cursor == len(e.samples) {
+			if err := e.flushFrame(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (e *opusEncoder) flushFrame() error {
+	data := make([]byte, 4000) // libopus never produces packets larger than this
+	n, err := e.enc.Encode(e.samples, data)
+	if err != nil {
+		return fmt.Errorf("audio/codec: opus: encode: %w", err)
+	}
+	e.cursor = 0
+	return writeFramed(e.w, data[:n])
+}
+
+func (e *opusEncoder) Close() error {
+	if e.cursor == 0 {
+		return nil
+	}
+	for i := e.cursor; i < len(e.samples); i++ {
+		e.samples[i] = 0
+	}
+	return e.flushFrame()
+}
+
+type opusDecoder struct {
+	r        io.Reader
+	dec      *opus.Decoder
+	channels int
+	pending  []byte
+}
+
+// NewOpusDecoder decodes an Opus stream framed by opusEncoder. Unlike the
+// other codecs, Opus packets carry no sample-rate/channel-count header of
+// their own, so the caller must supply the Format the stream was encoded
+// with.
+func NewOpusDecoder(r io.Reader, format Format) (io.Reader, error) {
+	dec, err := opus.NewDecoder(format.SampleRate, format.Channels)
+	if err != nil {
+		return nil, fmt.Errorf("audio/codec: opus: %w", err)
+	}
+	return &opusDecoder{r: r, dec: dec, channels: format.Channels}, nil
+}
+
+func (d *opusDecoder) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		packet, err := readFramed(d.r)
+		if err != nil {
+			return 0, err
+		}
+		pcm := make([]int16, 5760*d.channels) // 120ms at 48kHz, the largest Opus frame
+		n, err := d.dec.Decode(packet, pcm)
+		if err != nil {
+			return 0, fmt.Errorf("audio/codec: opus: decode: %w", err)
+		}
+		out := make([]byte, n*d.channels*2)
+		for i := 0; i < n*d.channels; i++ {
+			binary.LittleEndian.PutUint16(out[i*2:], uint16(pcm[i]))
+		}
+		d.pending = out
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+func writeFramed(w io.Writer, payload []byte) error {
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(payload)))
+	if _, err := w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func readFramed(r io.Reader) ([]byte, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+	payload := make([]byte, binary.BigEndian.Uint32(lenBuf[:]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
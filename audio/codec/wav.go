@@ -0,0 +1,158 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+func init() {
+	registerCodec("wav", newWAVEncoder, newWAVDecoder)
+}
+
+const (
+	bitsPerSample  = 16
+	wavHeaderSize  = 44
+	riffChunkSize  = 36 // everything after the 8-byte "RIFF"+size, minus the data chunk itself
+	fmtChunkSize   = 16 // PCM fmt chunk is always 16 bytes
+	audioFormatPCM = 1
+)
+
+// wavEncoder writes a canonical 44-byte PCM WAV header up front with
+// placeholder sizes, then streams PCM straight through. If w also
+// implements io.WriteSeeker, Close rewrites the header with the true sizes;
+// otherwise the sizes are left as 0xFFFFFFFF, which every WAV reader this
+// author has met treats as "read until EOF".
+type wavEncoder struct {
+	w        io.Writer
+	format   Format
+	written  uint32
+	wroteHdr bool
+}
+
+func newWAVEncoder(w io.Writer, format Format) (io.WriteCloser, error) {
+	if format.SampleRate <= 0 || format.Channels <= 0 {
+		return nil, fmt.Errorf("audio/codec: wav encoder requires a positive SampleRate and Channels")
+	}
+	e := &wavEncoder{w: w, format: format}
+	if err := e.writeHeader(0xFFFFFFFF); err != nil {
+		return nil, err
+	}
+	e.wroteHdr = true
+	return e, nil
+}
+
+func (e *wavEncoder) writeHeader(dataSize uint32) error {
+	var hdr [wavHeaderSize]byte
+	copy(hdr[0:4], "RIFF")
+	riffSize := uint32(0xFFFFFFFF)
+	if dataSize <= 0xFFFFFFFF-riffChunkSize {
+		riffSize = riffChunkSize + dataSize
+	}
+	binary.LittleEndian.PutUint32(hdr[4:8], riffSize)
+	copy(hdr[8:12], "WAVE")
+	copy(hdr[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(hdr[16:20], fmtChunkSize)
+	binary.LittleEndian.PutUint16(hdr[20:22], audioFormatPCM)
+	binary.LittleEndian.PutUint16(hdr[22:24], uint16(e.format.Channels))
+	binary.LittleEndian.PutUint32(hdr[24:28], uint32(e.format.SampleRate))
+	blockAlign := e.format.Channels * bitsPerSample / 8
+	byteRate := e.format.SampleRate * blockAlign
+	binary.LittleEndian.PutUint32(hdr[28:32], uint32(byteRate))
+	binary.LittleEndian.PutUint16(hdr[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(hdr[34:36], bitsPerSample)
+	copy(hdr[36:40], "data")
+	binary.LittleEndian.PutUint32(hdr[40:44], dataSize)
+
+	if seeker, ok := e.w.(io.WriteSeeker); ok && e.wroteHdr {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	_, err := e.w.Write(hdr[:])
+	return err
+}
+
+func (e *wavEncoder) Write(p []byte) (int, error) {
+	n, err := e.w.Write(p)
+	e.written += uint32(n)
+	return n, err
+}
+
+func (e *wavEncoder) Close() error {
+	if _, ok := e.w.(io.WriteSeeker); !ok {
+		return nil
+	}
+	return e.writeHeader(e.written)
+}
+
+// wavDecoder parses a RIFF/WAVE header and then hands back the raw data
+// chunk bytes, which are already 16-bit PCM.
+type wavDecoder struct {
+	r         io.Reader
+	remaining uint32
+}
+
+func newWAVDecoder(r io.Reader) (io.Reader, Format, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, Format{}, fmt.Errorf("audio/codec: wav: reading RIFF header: %w", err)
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, Format{}, fmt.Errorf("audio/codec: wav: not a RIFF/WAVE stream")
+	}
+
+	var format Format
+	var dataSize uint32
+	for {
+		var chunkHdr [8]byte
+		if _, err := io.ReadFull(r, chunkHdr[:]); err != nil {
+			return nil, Format{}, fmt.Errorf("audio/codec: wav: reading chunk header: %w", err)
+		}
+		id := string(chunkHdr[0:4])
+		size := binary.LittleEndian.Uint32(chunkHdr[4:8])
+
+		switch id {
+		case "fmt ":
+			var fmtChunk [fmtChunkSize]byte
+			if _, err := io.ReadFull(r, fmtChunk[:]); err != nil {
+				return nil, Format{}, fmt.Errorf("audio/codec: wav: reading fmt chunk: %w", err)
+			}
+			audioFormat := binary.LittleEndian.Uint16(fmtChunk[0:2])
+			channels := binary.LittleEndian.Uint16(fmtChunk[2:4])
+			sampleRate := binary.LittleEndian.Uint32(fmtChunk[4:8])
+			bits := binary.LittleEndian.Uint16(fmtChunk[14:16])
+			if audioFormat != audioFormatPCM {
+				return nil, Format{}, fmt.Errorf("audio/codec: wav: unsupported audio format %d (only PCM is supported)", audioFormat)
+			}
+			if bits != bitsPerSample {
+				return nil, Format{}, fmt.Errorf("audio/codec: wav: unsupported bit depth %d (only 16-bit PCM is supported)", bits)
+			}
+			format = Format{SampleRate: int(sampleRate), Channels: int(channels)}
+			if size > fmtChunkSize {
+				if _, err := io.CopyN(io.Discard, r, int64(size-fmtChunkSize)); err != nil {
+					return nil, Format{}, err
+				}
+			}
+		case "data":
+			dataSize = size
+			return &wavDecoder{r: r, remaining: dataSize}, format, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, int64(size)); err != nil {
+				return nil, Format{}, fmt.Errorf("audio/codec: wav: skipping chunk %q: %w", id, err)
+			}
+		}
+	}
+}
+
+func (d *wavDecoder) Read(p []byte) (int, error) {
+	if d.remaining == 0 {
+		return 0, io.EOF
+	}
+	if uint32(len(p)) > d.remaining {
+		p = p[:d.remaining]
+	}
+	n, err := d.r.Read(p)
+	d.remaining -= uint32(n)
+	return n, err
+}
@@ -0,0 +1,246 @@
+// Package mixer sums several independent PCM16 streams ("voices") into a
+// single interleaved stereo stream, so callers can layer sound effects and
+// music on top of each other before handing the result to a device's
+// playback Write (e.g. io.Copy(dev, mixer)). All voices and mixer output are
+// interleaved stereo PCM16 at a common sample rate; use the audio/resample
+// package to get a mono or differently-rated source into that shape first.
+package mixer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+)
+
+// DefaultVoices is the number of voice slots New creates when slots is 0.
+const DefaultVoices = 8
+
+// bytesPerFrame is the size of one interleaved stereo 16-bit PCM frame.
+const bytesPerFrame = 4
+
+// VoiceOptions controls how a voice is mixed.
+type VoiceOptions struct {
+	// Gain scales the voice's samples before mixing. 1 is unity, 0 is
+	// silent.
+	Gain float64
+	// Pan positions the voice in the stereo field, from -1 (hard left)
+	// through 0 (centre) to 1 (hard right).
+	Pan float64
+	// Loop rewinds the voice to its start on EOF instead of freeing its
+	// slot. This only works when the voice's Reader also implements
+	// io.Seeker; non-seekable voices are freed on EOF regardless.
+	Loop bool
+	// Priority decides which active voice Play steals when all slots are
+	// full: the lowest Priority loses.
+	Priority int
+}
+
+// Option sets a field on VoiceOptions.
+type Option func(*VoiceOptions)
+
+// WithGain sets a voice's Gain.
+func WithGain(gain float64) Option { return func(o *VoiceOptions) { o.Gain = gain } }
+
+// WithPan sets a voice's Pan.
+func WithPan(pan float64) Option { return func(o *VoiceOptions) { o.Pan = pan } }
+
+// WithLoop sets a voice's Loop flag.
+func WithLoop(loop bool) Option { return func(o *VoiceOptions) { o.Loop = loop } }
+
+// WithPriority sets a voice's Priority.
+func WithPriority(priority int) Option { return func(o *VoiceOptions) { o.Priority = priority } }
+
+func defaultVoiceOptions() VoiceOptions {
+	return VoiceOptions{Gain: 1}
+}
+
+type voice struct {
+	r    io.Reader
+	opts VoiceOptions
+}
+
+// Mixer sums up to N independent stereo PCM16 voices into a single output
+// stream, applying per-voice gain and pan and saturating on overflow so
+// several loud voices clip instead of wrapping around. A Mixer is an
+// io.Reader and safe for concurrent use.
+type Mixer struct {
+	mu     sync.Mutex
+	voices []*voice // nil entries are free slots
+}
+
+// New returns a Mixer with the given number of voice slots. slots <= 0
+// defaults to DefaultVoices.
+func New(slots int) *Mixer {
+	if slots <= 0 {
+		slots = DefaultVoices
+	}
+	return &Mixer{voices: make([]*voice, slots)}
+}
+
+// Voices returns the number of voice slots the Mixer was created with.
+func (m *Mixer) Voices() int {
+	return len(m.voices)
+}
+
+// PlaySlot starts r playing in the given slot, overwriting whatever was
+// already playing there.
+func (m *Mixer) PlaySlot(slot int, r io.Reader, opts ...Option) error {
+	if slot < 0 || slot >= len(m.voices) {
+		return fmt.Errorf("audio/mixer: slot %d out of range (have %d)", slot, len(m.voices))
+	}
+	o := defaultVoiceOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m.mu.Lock()
+	m.voices[slot] = &voice{r: r, opts: o}
+	m.mu.Unlock()
+	return nil
+}
+
+// Play starts r playing in the first free slot, or steals the
+// lowest-priority active voice if every slot is full. It returns the slot
+// used.
+func (m *Mixer) Play(r io.Reader, opts ...Option) int {
+	o := defaultVoiceOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, v := range m.voices {
+		if v == nil {
+			m.voices[i] = &voice{r: r, opts: o}
+			return i
+		}
+	}
+
+	steal := 0
+	for i, v := range m.voices {
+		if v.opts.Priority < m.voices[steal].opts.Priority {
+			steal = i
+		}
+	}
+	m.voices[steal] = &voice{r: r, opts: o}
+	return steal
+}
+
+// Stop silences the given slot, if it exists.
+func (m *Mixer) Stop(slot int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if slot >= 0 && slot < len(m.voices) {
+		m.voices[slot] = nil
+	}
+}
+
+// Read mixes len(p)/4 stereo frames from all active voices into p,
+// rounding down to a whole frame. Each active voice is read (via
+// readVoice) until it has supplied its share of p or returned an error, so
+// a voice whose own Read blocks also blocks Read; a voice that ends early
+// contributes silence for the rest of p and is freed. Read always succeeds
+// (err is always nil) since an idle Mixer is valid silence, not an error.
+func (m *Mixer) Read(p []byte) (int, error) {
+	frames := len(p) / bytesPerFrame
+	if frames == 0 {
+		return 0, nil
+	}
+
+	mix := make([]int32, frames*2)
+	buf := make([]byte, frames*bytesPerFrame)
+
+	m.mu.Lock()
+	for i, v := range m.voices {
+		if v == nil {
+			continue
+		}
+
+		n, err := readVoice(v.r, buf, v.opts.Loop)
+		if n > 0 {
+			mixVoice(mix, buf[:n], v.opts)
+		}
+
+		if err != nil {
+			m.voices[i] = nil
+		}
+	}
+	m.mu.Unlock()
+
+	for i, s := range mix {
+		writeClamped(p[i*2:], s)
+	}
+	return frames * bytesPerFrame, nil
+}
+
+// readVoice fills buf completely from r, the way io.ReadFull does, except
+// that when loop is set and r runs out mid-buffer, it seeks r back to the
+// start and keeps reading to fill the rest of buf in the same call instead
+// of leaving the remainder as silence. It gives up and returns whatever it
+// has read so far, plus the error, once a loop-seek itself makes no
+// progress (an empty clip) or r isn't an io.Seeker.
+func readVoice(r io.Reader, buf []byte, loop bool) (int, error) {
+	n := 0
+	seekedEmpty := false
+	for n < len(buf) {
+		nn, err := r.Read(buf[n:])
+		n += nn
+		if nn > 0 {
+			seekedEmpty = false
+		}
+		if err == nil {
+			continue
+		}
+		if loop && !seekedEmpty {
+			if seeker, ok := r.(io.Seeker); ok {
+				if _, serr := seeker.Seek(0, io.SeekStart); serr == nil {
+					seekedEmpty = nn == 0
+					continue
+				}
+			}
+		}
+		return n, err
+	}
+	return n, nil
+}
+
+// mixVoice adds pcm (interleaved stereo PCM16 bytes) into mix (per-sample
+// int32 accumulators), applying the voice's gain and constant-power pan.
+func mixVoice(mix []int32, pcm []byte, opts VoiceOptions) {
+	left, right := panGains(opts.Pan)
+	gainL, gainR := opts.Gain*left, opts.Gain*right
+
+	for i := 0; i+3 < len(pcm) && i+3 < len(mix)*2; i += 4 {
+		l := int16(pcm[i]) | int16(pcm[i+1])<<8
+		r := int16(pcm[i+2]) | int16(pcm[i+3])<<8
+		mix[i/2] += int32(float64(l) * gainL)
+		mix[i/2+1] += int32(float64(r) * gainR)
+	}
+}
+
+// panGains returns the constant-power left/right gain for a pan in
+// [-1, 1], clamping out-of-range values.
+func panGains(pan float64) (left, right float64) {
+	switch {
+	case pan < -1:
+		pan = -1
+	case pan > 1:
+		pan = 1
+	}
+	angle := (pan + 1) * math.Pi / 4
+	return math.Cos(angle), math.Sin(angle)
+}
+
+func writeClamped(p []byte, v int32) {
+	switch {
+	case v > 32767:
+		v = 32767
+	case v < -32768:
+		v = -32768
+	}
+	p[0] = byte(v)
+	p[1] = byte(v >> 8)
+}
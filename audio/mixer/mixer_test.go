@@ -0,0 +1,47 @@
+package mixer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// stereoClip packs amplitudes as identical left/right PCM16 frames.
+func stereoClip(amplitudes ...int16) []byte {
+	out := make([]byte, len(amplitudes)*bytesPerFrame)
+	for i, a := range amplitudes {
+		binary.LittleEndian.PutUint16(out[i*bytesPerFrame:], uint16(a))
+		binary.LittleEndian.PutUint16(out[i*bytesPerFrame+2:], uint16(a))
+	}
+	return out
+}
+
+// TestLoopFillsPartialBuffer covers a looping voice shorter than the
+// caller's Read buffer: the rewound reader must keep contributing audio for
+// the rest of that same Read call instead of leaving the remainder silent.
+func TestLoopFillsPartialBuffer(t *testing.T) {
+	// 3 frames of a distinctive non-zero stereo clip. Amplitudes are large
+	// enough to survive the default pan's ~0.707 gain without truncating to
+	// zero.
+	clip := bytes.NewReader(stereoClip(1000, 2000, 3000))
+
+	m := New(1)
+	m.PlaySlot(0, clip, WithLoop(true))
+
+	// Read 5 frames (20 bytes): one full loop of the 3-frame clip plus 2
+	// frames from the start of its second lap.
+	buf := make([]byte, 5*bytesPerFrame)
+	n, err := m.Read(buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if n != len(buf) {
+		t.Fatalf("n = %d, want %d", n, len(buf))
+	}
+
+	for i := 3 * bytesPerFrame; i < len(buf); i += 2 {
+		if buf[i] == 0 {
+			t.Fatalf("frame at byte %d is silence; loop did not refill the rest of the buffer: %v", i, buf)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package mixer
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// SoundBank preloads short interleaved stereo PCM16 clips by integer ID, so
+// callers can trigger sound effects by ID instead of managing io.Readers
+// themselves. A SoundBank holds no reference to any particular Mixer, so the
+// same bank can feed several mixers.
+type SoundBank struct {
+	mu    sync.RWMutex
+	clips map[int][]byte
+}
+
+// NewSoundBank returns an empty SoundBank.
+func NewSoundBank() *SoundBank {
+	return &SoundBank{clips: make(map[int][]byte)}
+}
+
+// Load registers pcm (interleaved stereo PCM16) under id, overwriting
+// whatever was previously loaded there.
+func (b *SoundBank) Load(id int, pcm []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.clips[id] = pcm
+}
+
+// Play starts the clip registered under id playing on m. If voice is
+// negative, m.Play picks the slot (first free, or stealing the
+// lowest-priority active voice); otherwise voice selects the slot
+// directly via m.PlaySlot, overwriting whatever was already playing
+// there. It returns the slot used.
+func (b *SoundBank) Play(m *Mixer, voice, id int, opts ...Option) (int, error) {
+	b.mu.RLock()
+	pcm, ok := b.clips[id]
+	b.mu.RUnlock()
+	if !ok {
+		return -1, fmt.Errorf("audio/mixer: no clip loaded for id %d", id)
+	}
+
+	if voice < 0 {
+		return m.Play(bytes.NewReader(pcm), opts...), nil
+	}
+	if err := m.PlaySlot(voice, bytes.NewReader(pcm), opts...); err != nil {
+		return -1, err
+	}
+	return voice, nil
+}
@@ -0,0 +1,101 @@
+// Package modem turns a raw PCM16 audio channel (an io.ReadWriter, such as a
+// Device or AudioIO) into an asynchronous serial link, the way an acoustic
+// modem turns a phone line into one. Bytes given to Write come out the other
+// end's Read as audio tones; bytes read back out the far end are recovered
+// from those tones.
+package modem
+
+import (
+	"fmt"
+	"io"
+)
+
+// oversample is how many timing "ticks" NewFSK divides each bit period into
+// while searching for a start bit. Once a frame is locked onto, each data
+// bit is classified from one clean, bit-aligned window instead.
+const oversample = 8
+
+// FSKConfig configures a Bell-103-like frequency-shift-keyed modem: each bit
+// is one of two audio tones, mark (binary 1, and the idle/resting tone) or
+// space (binary 0).
+type FSKConfig struct {
+	// SampleRate is the PCM sample rate of the audio channel being
+	// modulated, in Hz. Defaults to 48000.
+	SampleRate int
+	// BaudRate is the bit rate in bits/second. Defaults to 300, the
+	// original Bell 103 rate.
+	BaudRate int
+	// MarkFreq and SpaceFreq are the two tone frequencies in Hz. Default to
+	// 1270 and 1070, Bell 103's originate-mode mark and space tones.
+	MarkFreq  float64
+	SpaceFreq float64
+	// Amplitude scales the generated tones, from 0 to 1. Defaults to 0.8.
+	Amplitude float64
+}
+
+func (c FSKConfig) withDefaults() FSKConfig {
+	if c.SampleRate <= 0 {
+		c.SampleRate = 48000
+	}
+	if c.BaudRate <= 0 {
+		c.BaudRate = 300
+	}
+	if c.MarkFreq <= 0 {
+		c.MarkFreq = 1270
+	}
+	if c.SpaceFreq <= 0 {
+		c.SpaceFreq = 1070
+	}
+	if c.Amplitude <= 0 {
+		c.Amplitude = 0.8
+	}
+	return c
+}
+
+// fsk implements io.ReadWriteCloser over dev, encoding bytes written to it
+// as mark/space tones and decoding tones read from it back into bytes. Each
+// byte is framed the way an asynchronous UART frames one: a space start bit,
+// 8 data bits (LSB first), and a mark stop bit.
+type fsk struct {
+	dev io.ReadWriter
+	cfg FSKConfig
+
+	samplesPerBit int
+	subSamples    int // samples per oversampled timing tick, used for start-bit search
+
+	encPhase float64 // radians; kept continuous across tone/bit changes
+
+	decWindow  []float64 // sliding one-bit-period window used to search for a start bit
+	decLast    bool      // was the previous tick classified as mark?
+	decPending []byte
+}
+
+// NewFSK wraps dev, a mono 16-bit little-endian PCM channel at cfg's sample
+// rate, as an asynchronous FSK modem. Close closes dev if it implements
+// io.Closer.
+func NewFSK(dev io.ReadWriter, cfg FSKConfig) (io.ReadWriteCloser, error) {
+	cfg = cfg.withDefaults()
+
+	samplesPerBit := cfg.SampleRate / cfg.BaudRate
+	subSamples := samplesPerBit / oversample
+	if subSamples < 1 {
+		return nil, fmt.Errorf("audio/modem: sample rate %d is too low for %d baud (need at least %d Hz)",
+			cfg.SampleRate, cfg.BaudRate, cfg.BaudRate*oversample)
+	}
+
+	return &fsk{
+		dev:           dev,
+		cfg:           cfg,
+		samplesPerBit: samplesPerBit,
+		subSamples:    subSamples,
+		decWindow:     make([]float64, samplesPerBit),
+		decLast:       true, // the line idles at the mark tone
+	}, nil
+}
+
+func (f *fsk) Close() error {
+	if c, ok := f.dev.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package modem
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// Read decodes tones read from dev back into bytes. It blocks until at
+// least one full asynchronous frame has been decoded or dev.Read fails.
+func (f *fsk) Read(p []byte) (int, error) {
+	for len(f.decPending) == 0 {
+		b, err := f.decodeByte()
+		if err != nil {
+			return 0, err
+		}
+		f.decPending = append(f.decPending, b)
+	}
+
+	n := copy(p, f.decPending)
+	f.decPending = f.decPending[n:]
+	return n, nil
+}
+
+// decodeByte locates the next start-bit edge and decodes the byte it
+// introduces.
+//
+// Locating the edge is done at oversampled tick granularity: a sliding
+// one-bit-period window is classified tick by tick, and a mark-to-space
+// transition marks roughly the middle of the start bit (the window only
+// becomes space-dominant once the new tone has displaced about half of it).
+// From there, each remaining bit is read as one clean, bit-period-long,
+// non-overlapping chunk and classified with a single Goertzel pass, which
+// avoids the frequency-resolution and cross-bit-contamination problems a
+// continuously sliding window would otherwise have for data bits.
+func (f *fsk) decodeByte() (byte, error) {
+	// Start each frame search with a clean slate: the data-bit phase below
+	// doesn't keep decWindow updated, so reusing it across bytes would have
+	// the next search start against stale, already-consumed audio.
+	for i := range f.decWindow {
+		f.decWindow[i] = 0
+	}
+	f.decLast = true // the line idles/ends a frame at the mark tone
+
+	for {
+		tick, err := f.readSamples(f.subSamples)
+		if err != nil {
+			return 0, err
+		}
+		copy(f.decWindow, f.decWindow[f.subSamples:])
+		copy(f.decWindow[len(f.decWindow)-f.subSamples:], tick)
+
+		mark := classify(f.decWindow, f.cfg.MarkFreq, f.cfg.SpaceFreq, f.cfg.SampleRate)
+		edge := f.decLast && !mark
+		f.decLast = mark
+		if edge {
+			break
+		}
+	}
+
+	// The edge fired roughly half-way into the start bit; consume the rest
+	// of it before data bits begin.
+	if _, err := f.readSamples(f.samplesPerBit / 2); err != nil {
+		return 0, err
+	}
+
+	var b byte
+	for bit := 0; bit < 8; bit++ {
+		samples, err := f.readSamples(f.samplesPerBit)
+		if err != nil {
+			return 0, err
+		}
+		if classify(samples, f.cfg.MarkFreq, f.cfg.SpaceFreq, f.cfg.SampleRate) {
+			b |= 1 << uint(bit)
+		}
+	}
+
+	// Stop bit: read and discard. A real link would flag a missing mark
+	// here as a framing error and attempt resync; this modem just trusts
+	// the edge it locked onto.
+	if _, err := f.readSamples(f.samplesPerBit); err != nil {
+		return 0, err
+	}
+
+	return b, nil
+}
+
+// readSamples reads n PCM16 samples from dev and returns them as float64s.
+func (f *fsk) readSamples(n int) ([]float64, error) {
+	raw := make([]byte, n*2)
+	if _, err := io.ReadFull(f.dev, raw); err != nil {
+		return nil, err
+	}
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = float64(int16(binary.LittleEndian.Uint16(raw[i*2:])))
+	}
+	return samples, nil
+}
+
+// classify reports whether samples looks more like the mark or space tone,
+// by comparing Goertzel power at each frequency.
+func classify(samples []float64, markFreq, spaceFreq float64, sampleRate int) bool {
+	return goertzelPower(samples, markFreq, sampleRate) >= goertzelPower(samples, spaceFreq, sampleRate)
+}
+
+// goertzelPower measures the power of samples at targetFreq using the
+// Goertzel algorithm, equivalent to the squared magnitude of a single DFT
+// coefficient but far cheaper than a full FFT when only a couple of
+// frequencies are of interest. targetFreq is used as given rather than
+// rounded to the nearest DFT bin, so two tones closer together than
+// sampleRate/len(samples) are still measured separately.
+func goertzelPower(samples []float64, targetFreq float64, sampleRate int) float64 {
+	n := len(samples)
+	if n == 0 {
+		return 0
+	}
+
+	omega := 2 * math.Pi * targetFreq / float64(sampleRate)
+	coeff := 2 * math.Cos(omega)
+
+	var s0, s1, s2 float64
+	for _, x := range samples {
+		s0 = x + coeff*s1 - s2
+		s2 = s1
+		s1 = s0
+	}
+	return s1*s1 + s2*s2 - coeff*s1*s2
+}
@@ -0,0 +1,59 @@
+package modem
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Write encodes each byte of p as an asynchronous frame (space start bit, 8
+// data bits LSB first, mark stop bit) and writes the resulting tones to
+// dev, one Write per source byte so dev sees each frame as a single
+// contiguous chunk rather than ten separate per-bit writes. It returns the
+// number of whole bytes from p successfully written.
+func (f *fsk) Write(p []byte) (int, error) {
+	frame := make([]byte, 0, 10*f.samplesPerBit*2)
+	for i, b := range p {
+		frame = frame[:0]
+		for bit := -1; bit < 9; bit++ {
+			var mark bool
+			switch {
+			case bit == -1: // start bit
+				mark = false
+			case bit == 8: // stop bit
+				mark = true
+			default:
+				mark = (b>>uint(bit))&1 == 1
+			}
+			frame = append(frame, f.renderTone(mark)...)
+		}
+
+		if _, err := f.dev.Write(frame); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// renderTone returns samplesPerBit samples of PCM16 at the mark or space
+// frequency, continuing the encoder's phase accumulator from wherever the
+// previous tone left off so tone changes don't introduce a phase
+// discontinuity (an audible click) at the boundary.
+func (f *fsk) renderTone(mark bool) []byte {
+	freq := f.cfg.SpaceFreq
+	if mark {
+		freq = f.cfg.MarkFreq
+	}
+
+	out := make([]byte, f.samplesPerBit*2)
+	inc := 2 * math.Pi * freq / float64(f.cfg.SampleRate)
+	for i := 0; i < f.samplesPerBit; i++ {
+		sample := int16(f.cfg.Amplitude * 32767 * math.Sin(f.encPhase))
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(sample))
+
+		f.encPhase += inc
+		if f.encPhase > 2*math.Pi {
+			f.encPhase -= 2 * math.Pi
+		}
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package modem
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// memChannel is a minimal stand-in for a real audio device's ring buffer:
+// Write appends and returns immediately, Read blocks until there's
+// something to read. Unlike io.Pipe, a Write never waits on a matching
+// Read, which is how an actual device (and the rest of this module) behaves.
+type memChannel struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	buf  bytes.Buffer
+}
+
+func newMemChannel() *memChannel {
+	c := &memChannel{}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *memChannel) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, err := c.buf.Write(p)
+	c.cond.Broadcast()
+	return n, err
+}
+
+func (c *memChannel) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.buf.Len() == 0 {
+		c.cond.Wait()
+	}
+	return c.buf.Read(p)
+}
+
+// testConfig uses tones further apart than Bell 103's real 1070/1270Hz pair
+// so the Goertzel bins stay well separated even at this test's relatively
+// short bit period, keeping the test fast without sacrificing reliability.
+func testConfig() FSKConfig {
+	return FSKConfig{SampleRate: 48000, BaudRate: 300, MarkFreq: 2000, SpaceFreq: 1000}
+}
+
+func TestFSKLoopback(t *testing.T) {
+	channel := newMemChannel()
+
+	tx, err := NewFSK(channel, testConfig())
+	if err != nil {
+		t.Fatalf("NewFSK (tx): %v", err)
+	}
+	rx, err := NewFSK(channel, testConfig())
+	if err != nil {
+		t.Fatalf("NewFSK (rx): %v", err)
+	}
+
+	want := []byte("the quick brown fox jumps over the lazy dog 0123456789")
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := tx.Write(want)
+		errCh <- err
+	}()
+
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(rx, got); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("byte-perfect delivery failed:\n got:  %q\n want: %q", got, want)
+	}
+}
+
+// TestFSKWithNoise injects additive white Gaussian noise into the audio
+// channel between encoder and decoder and reports how many decoded bytes
+// differ from those sent. It doesn't assert an error bound (AFSK's robustness to
+// noise is not the point under test, byte framing is); it just exercises
+// the decoder against a non-ideal channel and reports the result.
+func TestFSKWithNoise(t *testing.T) {
+	channel := newMemChannel()
+	noisy := &noisyWriter{w: channel, rnd: rand.New(rand.NewSource(1)), sigma: 4000}
+
+	tx, err := NewFSK(noisy, testConfig())
+	if err != nil {
+		t.Fatalf("NewFSK (tx): %v", err)
+	}
+	rx, err := NewFSK(channel, testConfig())
+	if err != nil {
+		t.Fatalf("NewFSK (rx): %v", err)
+	}
+
+	want := []byte("noisy channel test message")
+
+	go func() { _, _ = tx.Write(want) }()
+
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(rx, got); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+
+	mismatches := 0
+	for i := range want {
+		if got[i] != want[i] {
+			mismatches++
+		}
+	}
+	t.Logf("byte error characterization: %d/%d bytes mismatched under sigma=4000 AWGN", mismatches, len(want))
+}
+
+// noisyWriter adds Gaussian noise to a PCM16 stream before forwarding it to
+// w, simulating a lossy audio channel. Its Read is never called by a
+// transmitter; it exists only so noisyWriter satisfies the io.ReadWriter
+// that NewFSK takes.
+type noisyWriter struct {
+	w     io.Writer
+	rnd   *rand.Rand
+	sigma float64
+}
+
+func (n *noisyWriter) Read(p []byte) (int, error) { return 0, io.EOF }
+
+func (n *noisyWriter) Write(p []byte) (int, error) {
+	out := make([]byte, len(p))
+	copy(out, p)
+	for i := 0; i+1 < len(out); i += 2 {
+		v := int16(binary.LittleEndian.Uint16(out[i:]))
+		noisy := float64(v) + n.rnd.NormFloat64()*n.sigma
+		binary.LittleEndian.PutUint16(out[i:], uint16(int16(noisy)))
+	}
+	return n.w.Write(out)
+}
@@ -0,0 +1,188 @@
+// Package resample converts interleaved PCM16 audio between sample rates and
+// channel counts, so callers producing audio at one rate (e.g. a 16 kHz
+// speech model) can feed a device running at another (e.g. a 48 kHz audio
+// card) without doing the math themselves.
+package resample
+
+import "math"
+
+// Method selects the interpolation algorithm a Resampler uses when changing
+// the sample rate.
+type Method int
+
+const (
+	// Linear uses straight-line interpolation between neighbouring samples.
+	// Cheap, and adequate for speech-grade audio.
+	Linear Method = iota
+	// Sinc uses a windowed-sinc (Lanczos) FIR kernel. Slower, but preserves
+	// high frequencies much better than Linear.
+	Sinc
+)
+
+// Format describes the interleaved PCM16 layout a Resampler converts
+// between.
+type Format struct {
+	SampleRate int
+	Channels   int
+}
+
+// Resampler converts interleaved 16-bit PCM from one Format to another,
+// remixing channel count (mono<->stereo, or N-channel down/up-mix) as well
+// as sample rate. A Resampler is not safe for concurrent use.
+//
+// Process is self-contained per call: it does not carry fractional phase
+// across calls, so very small buffers can introduce a faint click at chunk
+// boundaries. Feeding it consistently sized buffers of at least a few
+// milliseconds keeps this inaudible.
+type Resampler struct {
+	from, to Format
+	method   Method
+	taps     int
+}
+
+// New returns a Resampler converting from 'from' to 'to'. taps sets the
+// half-width of the windowed-sinc kernel used when method is Sinc, and is
+// ignored for Linear; a taps of 0 defaults to 8.
+func New(from, to Format, method Method, taps int) *Resampler {
+	if taps <= 0 {
+		taps = 8
+	}
+	return &Resampler{from: from, to: to, method: method, taps: taps}
+}
+
+// Process remixes and resamples in (interleaved PCM16 at r.from's rate and
+// channel count), returning PCM16 at r.to's rate and channel count.
+func (r *Resampler) Process(in []int16) []int16 {
+	out := remix(in, r.from.Channels, r.to.Channels)
+	if r.from.SampleRate == r.to.SampleRate || len(out) == 0 {
+		return out
+	}
+	ratio := float64(r.from.SampleRate) / float64(r.to.SampleRate)
+	if r.method == Sinc {
+		return resampleSinc(out, r.to.Channels, ratio, r.taps)
+	}
+	return resampleLinear(out, r.to.Channels, ratio)
+}
+
+// remix converts interleaved frames with fromCh channels to toCh channels,
+// leaving the frame rate unchanged. Unsupported channel counts fall back to
+// averaging all input channels into every output channel.
+func remix(in []int16, fromCh, toCh int) []int16 {
+	if fromCh <= 0 {
+		fromCh = 1
+	}
+	if toCh <= 0 {
+		toCh = 1
+	}
+	if fromCh == toCh {
+		return in
+	}
+
+	frames := len(in) / fromCh
+	out := make([]int16, frames*toCh)
+	for i := 0; i < frames; i++ {
+		frame := in[i*fromCh : i*fromCh+fromCh]
+		switch {
+		case fromCh == 1 && toCh == 2:
+			out[i*2] = frame[0]
+			out[i*2+1] = frame[0]
+		case fromCh == 2 && toCh == 1:
+			out[i] = int16((int32(frame[0]) + int32(frame[1])) / 2)
+		default:
+			var sum int32
+			for _, s := range frame {
+				sum += int32(s)
+			}
+			avg := int16(sum / int32(fromCh))
+			for c := 0; c < toCh; c++ {
+				out[i*toCh+c] = avg
+			}
+		}
+	}
+	return out
+}
+
+// resampleLinear rate-converts already-remixed (ch-channel) PCM16 using
+// straight-line interpolation. ratio is fromRate/toRate.
+func resampleLinear(in []int16, ch int, ratio float64) []int16 {
+	frames := len(in) / ch
+	if frames == 0 {
+		return nil
+	}
+	outFrames := int(float64(frames) / ratio)
+	out := make([]int16, outFrames*ch)
+	for i := 0; i < outFrames; i++ {
+		pos := float64(i) * ratio
+		i0 := int(pos)
+		if i0 >= frames {
+			i0 = frames - 1
+		}
+		i1 := i0 + 1
+		if i1 >= frames {
+			i1 = frames - 1
+		}
+		frac := pos - float64(i0)
+		for c := 0; c < ch; c++ {
+			s0 := float64(in[i0*ch+c])
+			s1 := float64(in[i1*ch+c])
+			out[i*ch+c] = int16(s0 + (s1-s0)*frac)
+		}
+	}
+	return out
+}
+
+// resampleSinc rate-converts already-remixed (ch-channel) PCM16 with a
+// windowed-sinc (Lanczos) FIR kernel of the given half-width. ratio is
+// fromRate/toRate.
+func resampleSinc(in []int16, ch int, ratio float64, taps int) []int16 {
+	frames := len(in) / ch
+	if frames == 0 {
+		return nil
+	}
+	outFrames := int(float64(frames) / ratio)
+	out := make([]int16, outFrames*ch)
+	a := float64(taps)
+	for i := 0; i < outFrames; i++ {
+		center := float64(i) * ratio
+		lo := int(math.Floor(center)) - taps + 1
+		hi := int(math.Floor(center)) + taps
+		for c := 0; c < ch; c++ {
+			var sum, norm float64
+			for j := lo; j <= hi; j++ {
+				if j < 0 || j >= frames {
+					continue
+				}
+				w := lanczos(center-float64(j), a)
+				sum += w * float64(in[j*ch+c])
+				norm += w
+			}
+			if norm != 0 {
+				sum /= norm
+			}
+			out[i*ch+c] = clampInt16(sum)
+		}
+	}
+	return out
+}
+
+func lanczos(x, a float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	if x < -a || x > a {
+		return 0
+	}
+	piX := math.Pi * x
+	return a * math.Sin(piX) * math.Sin(piX/a) / (piX * piX)
+}
+
+func clampInt16(v float64) int16 {
+	switch {
+	case v > 32767:
+		return 32767
+	case v < -32768:
+		return -32768
+	default:
+		return int16(v)
+	}
+}
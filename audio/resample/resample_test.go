@@ -0,0 +1,90 @@
+package resample
+
+import "testing"
+
+// TestPassthroughSameRate covers the same-rate, same-channel case: Process
+// should hand the input back unchanged, not run it through the resampling
+// math at all.
+func TestPassthroughSameRate(t *testing.T) {
+	format := Format{SampleRate: 16000, Channels: 1}
+	r := New(format, format, Linear, 0)
+
+	in := []int16{100, -200, 300, -400, 500}
+	out := r.Process(in)
+
+	if len(out) != len(in) {
+		t.Fatalf("len(out) = %d, want %d", len(out), len(in))
+	}
+	for i := range in {
+		if out[i] != in[i] {
+			t.Fatalf("out[%d] = %d, want %d (unchanged)", i, out[i], in[i])
+		}
+	}
+}
+
+// TestFrameCountMatchesRatio covers known up/downsample ratios: the output
+// frame count should track from/to sample rate, not just pass the input
+// length through.
+func TestFrameCountMatchesRatio(t *testing.T) {
+	cases := []struct {
+		name          string
+		from, to      int
+		inFrames      int
+		wantOutFrames int
+	}{
+		{"upsample 2x", 8000, 16000, 100, 200},
+		{"downsample 2x", 16000, 8000, 100, 50},
+		{"downsample 3x", 48000, 16000, 300, 100},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := New(Format{SampleRate: c.from, Channels: 1}, Format{SampleRate: c.to, Channels: 1}, Linear, 0)
+			in := make([]int16, c.inFrames)
+			for i := range in {
+				in[i] = int16(i)
+			}
+			out := r.Process(in)
+			if len(out) != c.wantOutFrames {
+				t.Fatalf("len(out) = %d, want %d", len(out), c.wantOutFrames)
+			}
+		})
+	}
+}
+
+// TestRemixMonoStereo covers channel conversion independent of rate: mono
+// frames must be duplicated to both stereo channels, and stereo frames
+// averaged down to mono.
+func TestRemixMonoStereo(t *testing.T) {
+	format16k := func(ch int) Format { return Format{SampleRate: 16000, Channels: ch} }
+
+	t.Run("mono to stereo", func(t *testing.T) {
+		r := New(format16k(1), format16k(2), Linear, 0)
+		out := r.Process([]int16{1000, -2000})
+
+		want := []int16{1000, 1000, -2000, -2000}
+		if len(out) != len(want) {
+			t.Fatalf("len(out) = %d, want %d", len(out), len(want))
+		}
+		for i := range want {
+			if out[i] != want[i] {
+				t.Fatalf("out[%d] = %d, want %d", i, out[i], want[i])
+			}
+		}
+	})
+
+	t.Run("stereo to mono", func(t *testing.T) {
+		r := New(format16k(2), format16k(1), Linear, 0)
+		out := r.Process([]int16{1000, 3000, -2000, -4000})
+
+		want := []int16{2000, -3000}
+		if len(out) != len(want) {
+			t.Fatalf("len(out) = %d, want %d", len(out), len(want))
+		}
+		for i := range want {
+			if out[i] != want[i] {
+				t.Fatalf("out[%d] = %d, want %d", i, out[i], want[i])
+			}
+		}
+	})
+}
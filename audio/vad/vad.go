@@ -0,0 +1,224 @@
+// Package vad wraps a PCM16 io.Reader (such as a Device or AudioIO capture
+// stream) so that only frames containing speech are emitted, dropping
+// silence before it ever reaches a caller such as an STT pipeline. It
+// detects speech with a simple energy + zero-crossing-rate test against an
+// adaptive noise floor, the way a cheap hardware squelch would, rather than
+// a trained model.
+package vad
+
+import (
+	"io"
+	"math"
+	"time"
+)
+
+// EventType identifies an utterance boundary VAD reports.
+type EventType int
+
+const (
+	// UtteranceStart fires on the first speech frame after silence (or
+	// stream start).
+	UtteranceStart EventType = iota
+	// UtteranceEnd fires once Hangover has elapsed with no further speech
+	// frames.
+	UtteranceEnd
+)
+
+// Event reports an utterance boundary.
+type Event struct {
+	Type EventType
+}
+
+// VADConfig configures the energy/ZCR detector.
+type VADConfig struct {
+	// SampleRate is the PCM sample rate of r, in Hz. Defaults to 48000.
+	SampleRate int
+	// FrameDuration is the analysis window size. Defaults to 20ms.
+	FrameDuration time.Duration
+	// Hangover is how long speech must be absent before an utterance is
+	// declared ended, so a brief pause mid-sentence doesn't split it into
+	// two. Defaults to 300ms.
+	Hangover time.Duration
+	// NoiseFloorAlpha is the EMA smoothing factor used to track the noise
+	// floor from frames declared silent: floor = alpha*floor +
+	// (1-alpha)*frameRMS. Closer to 1 adapts more slowly. Defaults to 0.95.
+	NoiseFloorAlpha float64
+	// ThresholdFactor sets the energy threshold above the noise floor that
+	// counts as speech: threshold = noiseFloor * ThresholdFactor. Defaults
+	// to 2.5.
+	ThresholdFactor float64
+	// OnEvent, if set, is called synchronously from Read whenever an
+	// utterance starts or ends.
+	OnEvent func(Event)
+	// Events, if set, receives the same utterance-boundary events as
+	// OnEvent. Read drops an event rather than block if the channel isn't
+	// ready to receive it, so a slow consumer can't stall audio delivery.
+	Events chan<- Event
+}
+
+func (c VADConfig) withDefaults() VADConfig {
+	if c.SampleRate <= 0 {
+		c.SampleRate = 48000
+	}
+	if c.FrameDuration <= 0 {
+		c.FrameDuration = 20 * time.Millisecond
+	}
+	if c.Hangover <= 0 {
+		c.Hangover = 300 * time.Millisecond
+	}
+	if c.NoiseFloorAlpha <= 0 {
+		c.NoiseFloorAlpha = 0.95
+	}
+	if c.ThresholdFactor <= 0 {
+		c.ThresholdFactor = 2.5
+	}
+	return c
+}
+
+// vad implements io.Reader over r: it pulls whole frames from r, decides each is speech or
+// silence, and only copies speech frames (plus the trailing hangover) into
+// the caller's buffer.
+type vad struct {
+	r   io.Reader
+	cfg VADConfig
+
+	frameBytes     int // one mono PCM16 analysis frame, in bytes
+	hangoverFrames int // frames of silence tolerated before UtteranceEnd fires
+	hangoverLeft   int // frames of silence remaining before UtteranceEnd fires
+
+	noiseFloor float64
+	noiseInit  bool // has noiseFloor been seeded from a real frame yet?
+	speaking   bool
+
+	pending []byte // speech bytes decoded but not yet copied out via Read
+}
+
+// NewVAD wraps r, a mono 16-bit little-endian PCM stream at cfg.SampleRate,
+// so that Read only returns frames containing speech.
+func NewVAD(r io.Reader, cfg VADConfig) io.Reader {
+	cfg = cfg.withDefaults()
+
+	frameSamples := cfg.SampleRate * int(cfg.FrameDuration/time.Millisecond) / 1000
+	if frameSamples < 1 {
+		frameSamples = 1
+	}
+	hangoverFrames := int(cfg.Hangover / cfg.FrameDuration)
+
+	return &vad{
+		r:              r,
+		cfg:            cfg,
+		frameBytes:     frameSamples * 2,
+		hangoverFrames: hangoverFrames,
+	}
+}
+
+// Read returns only the speech portion of r's stream: it pulls and
+// classifies whole analysis frames from r until at least one is speech (or
+// still within hangover of the last speech frame), then copies it into p.
+func (v *vad) Read(p []byte) (int, error) {
+	for len(v.pending) == 0 {
+		frame := make([]byte, v.frameBytes)
+		n, err := io.ReadFull(v.r, frame)
+		if n == 0 && err != nil {
+			return 0, err
+		}
+		frame = frame[:n]
+
+		if v.classify(frame) {
+			v.pending = frame
+		}
+		if err != nil {
+			if len(v.pending) == 0 {
+				return 0, err
+			}
+			break
+		}
+	}
+
+	n := copy(p, v.pending)
+	v.pending = v.pending[n:]
+	return n, nil
+}
+
+// classify updates the detector's state from one analysis frame and reports
+// whether it should be emitted: either because it's speech, or because it
+// falls within the hangover period following the last speech frame.
+func (v *vad) classify(frame []byte) bool {
+	rms := frameRMS(frame)
+	if !v.noiseInit {
+		// Seed from the very first frame rather than leaving noiseFloor at
+		// its zero value, which would make rms > 0 (i.e. virtually any
+		// real audio) look like speech forever.
+		v.noiseFloor = rms
+		v.noiseInit = true
+	}
+	speech := rms > v.noiseFloor*v.cfg.ThresholdFactor && zeroCrossingRate(frame) > 0
+
+	if speech {
+		if !v.speaking {
+			v.fireEvent(Event{Type: UtteranceStart})
+		}
+		v.speaking = true
+		v.hangoverLeft = v.hangoverFrames
+		return true
+	}
+
+	v.noiseFloor = v.cfg.NoiseFloorAlpha*v.noiseFloor + (1-v.cfg.NoiseFloorAlpha)*rms
+
+	if v.speaking && v.hangoverLeft > 0 {
+		v.hangoverLeft--
+		return true
+	}
+	if v.speaking {
+		v.speaking = false
+		v.fireEvent(Event{Type: UtteranceEnd})
+	}
+	return false
+}
+
+func (v *vad) fireEvent(e Event) {
+	if v.cfg.OnEvent != nil {
+		v.cfg.OnEvent(e)
+	}
+	if v.cfg.Events != nil {
+		select {
+		case v.cfg.Events <- e:
+		default:
+		}
+	}
+}
+
+// frameRMS returns the root-mean-square amplitude of frame, interpreted as
+// little-endian PCM16 samples.
+func frameRMS(frame []byte) float64 {
+	n := len(frame) / 2
+	if n == 0 {
+		return 0
+	}
+	var sumSq float64
+	for i := 0; i < n; i++ {
+		s := float64(int16(frame[i*2]) | int16(frame[i*2+1])<<8)
+		sumSq += s * s
+	}
+	return math.Sqrt(sumSq / float64(n))
+}
+
+// zeroCrossingRate returns the fraction of adjacent sample pairs in frame
+// that change sign, a cheap proxy for how "voiced"/noisy a frame sounds as
+// opposed to a smooth low-frequency rumble.
+func zeroCrossingRate(frame []byte) float64 {
+	n := len(frame) / 2
+	if n < 2 {
+		return 0
+	}
+	crossings := 0
+	prev := int16(frame[0]) | int16(frame[1])<<8
+	for i := 1; i < n; i++ {
+		cur := int16(frame[i*2]) | int16(frame[i*2+1])<<8
+		if (prev < 0) != (cur < 0) {
+			crossings++
+		}
+		prev = cur
+	}
+	return float64(crossings) / float64(n-1)
+}
@@ -0,0 +1,79 @@
+package vad
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+)
+
+// tone returns frames samples of PCM16 at freq/amplitude, sampleRate Hz.
+func tone(frames int, freq, amplitude float64, sampleRate int) []byte {
+	out := make([]byte, frames*2)
+	for i := 0; i < frames; i++ {
+		s := int16(amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(s))
+	}
+	return out
+}
+
+// TestTrimsSilence feeds a silence/speech/silence sequence through VAD and
+// asserts the silence portions are actually dropped, not just the speech
+// portion passed through unfiltered.
+func TestTrimsSilence(t *testing.T) {
+	const sampleRate = 16000
+
+	var in bytes.Buffer
+	in.Write(tone(sampleRate, 60, 50, sampleRate))       // 1s quiet background hiss
+	in.Write(tone(sampleRate/2, 300, 12000, sampleRate)) // 0.5s loud "speech"
+	in.Write(tone(sampleRate, 60, 50, sampleRate))       // 1s quiet background hiss again
+
+	v := NewVAD(&in, VADConfig{SampleRate: sampleRate})
+
+	out, err := io.ReadAll(v)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	totalBytes := (sampleRate*2 + sampleRate/2) * 2
+	if len(out) == totalBytes {
+		t.Fatalf("no silence was trimmed: got %d bytes out of %d bytes in", len(out), totalBytes)
+	}
+	if len(out) == 0 {
+		t.Fatalf("speech portion was dropped entirely")
+	}
+	// Expect roughly the 0.5s speech stretch plus the trailing 300ms
+	// hangover, and not much more: the bulk of the two 1s silence
+	// stretches must be gone.
+	speechBytes := (sampleRate / 2) * 2
+	hangoverBytes := (sampleRate * 3 / 10) * 2
+	if max := speechBytes + hangoverBytes + 4000; len(out) > max {
+		t.Fatalf("too much audio passed through: got %d bytes, want well under %d", len(out), max)
+	}
+}
+
+func TestFiresUtteranceEvents(t *testing.T) {
+	const sampleRate = 16000
+
+	var in bytes.Buffer
+	in.Write(tone(sampleRate, 60, 50, sampleRate))
+	in.Write(tone(sampleRate/2, 300, 12000, sampleRate))
+	in.Write(tone(sampleRate, 60, 50, sampleRate))
+
+	events := make(chan Event, 8)
+	v := NewVAD(&in, VADConfig{SampleRate: sampleRate, Events: events})
+
+	if _, err := io.ReadAll(v); err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	close(events)
+
+	var got []EventType
+	for e := range events {
+		got = append(got, e.Type)
+	}
+	if len(got) < 2 || got[0] != UtteranceStart || got[len(got)-1] != UtteranceEnd {
+		t.Fatalf("expected a start followed eventually by an end, got %v", got)
+	}
+}
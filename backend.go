@@ -0,0 +1,117 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// defaultSampleRate is used by backends when Config.SampleRate is unset.
+const defaultSampleRate = 48_000
+
+// BackendInfo describes the sample format and latency a Backend was opened
+// with, so callers can adapt their PCM framing without re-deriving it from
+// Config.
+type BackendInfo struct {
+	Name       string
+	SampleRate int
+	Channels   int
+	Latency    time.Duration
+}
+
+// Backend is the common surface implemented by every audio driver in this
+// module (portaudio, beep+microphone, oto). OpenCapture/OpenPlayback are
+// independent: a backend that only supports one direction returns an error
+// for the other, and callers that only need one direction may call only
+// that method.
+type Backend interface {
+	// OpenCapture opens the microphone/input side of the backend, returning
+	// a reader of 16-bit PCM samples.
+	OpenCapture() (io.Reader, error)
+	// OpenPlayback opens the speaker/output side of the backend, returning a
+	// writer of 16-bit PCM samples.
+	OpenPlayback() (io.Writer, error)
+	// Info describes the sample format and latency the backend was opened
+	// with.
+	Info() BackendInfo
+	// Close releases any resources (streams, native contexts) held by the
+	// backend.
+	Close() error
+}
+
+// EnvBackend overrides the default backend selected by Open when set and no
+// WithBackend option is given.
+const EnvBackend = "AUDIO_BACKEND"
+
+// preferredBackendOrder is the order Open tries backends in when none is
+// requested explicitly. portaudio and beep always need cgo and libportaudio;
+// oto needs at most a single small system audio library (and none at all on
+// Windows/macOS/WASM), so it is the last resort.
+var preferredBackendOrder = []string{"portaudio", "beep", "oto"}
+
+var backendRegistry = map[string]func(Config) (Backend, error){}
+
+// registerBackend makes a backend constructor available to Open under name.
+// Backend implementations call this from an init func, so that builds which
+// exclude a backend's source file (e.g. cgo-gated files under
+// CGO_ENABLED=0) simply don't register it instead of failing to compile.
+func registerBackend(name string, ctor func(Config) (Backend, error)) {
+	backendRegistry[name] = ctor
+}
+
+// Option configures how Open selects a backend.
+type Option func(*openOptions)
+
+type openOptions struct {
+	backendName string
+}
+
+// WithBackend selects a backend by name ("portaudio", "beep", or "oto"),
+// overriding both the AUDIO_BACKEND environment variable and the default
+// preference order.
+func WithBackend(name string) Option {
+	return func(o *openOptions) { o.backendName = name }
+}
+
+// Open selects and opens a Backend. The backend is chosen, in priority
+// order, from: an explicit WithBackend option, the AUDIO_BACKEND
+// environment variable, then the first of portaudio/beep/oto that was
+// compiled into the binary. cfg configures the opened backend; fields not
+// meaningful to the selected backend are ignored.
+func Open(cfg Config, opts ...Option) (Backend, error) {
+	var o openOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	name := o.backendName
+	if name == "" {
+		name = os.Getenv(EnvBackend)
+	}
+	if name == "" {
+		for _, candidate := range preferredBackendOrder {
+			if _, ok := backendRegistry[candidate]; ok {
+				name = candidate
+				break
+			}
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("audio: no backend available (built without portaudio, beep, or oto support)")
+	}
+
+	ctor, ok := backendRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("audio: unknown backend %q (available: %v)", name, registeredBackends())
+	}
+	return ctor(cfg)
+}
+
+func registeredBackends() []string {
+	names := make([]string, 0, len(backendRegistry))
+	for name := range backendRegistry {
+		names = append(names, name)
+	}
+	return names
+}
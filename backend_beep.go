@@ -0,0 +1,69 @@
+//go:build cgo
+
+package audio
+
+import (
+	"io"
+	"sync"
+)
+
+func init() {
+	registerBackend("beep", newBeepBackend)
+}
+
+// beepBackend adapts AudioIO (beep playback + microphone capture) to the
+// Backend interface.
+type beepBackend struct {
+	cfg Config
+
+	mu  sync.Mutex
+	io  *AudioIO
+	err error
+}
+
+func newBeepBackend(cfg Config) (Backend, error) {
+	return &beepBackend{cfg: cfg}, nil
+}
+
+func (b *beepBackend) audioIO() (*AudioIO, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.io != nil || b.err != nil {
+		return b.io, b.err
+	}
+
+	cfg := b.cfg
+	if cfg.PlaySampleRate == 0 {
+		cfg.PlaySampleRate = cfg.SampleRate
+	}
+	if cfg.CaptureSampleRate == 0 {
+		cfg.CaptureSampleRate = cfg.SampleRate
+	}
+	if cfg.PlayLatency == 0 {
+		cfg.PlayLatency = cfg.Latency
+	}
+	if cfg.CaptureLatency == 0 {
+		cfg.CaptureLatency = cfg.Latency
+	}
+
+	b.io, b.err = NewAudioIO(cfg)
+	return b.io, b.err
+}
+
+func (b *beepBackend) OpenCapture() (io.Reader, error) { return b.audioIO() }
+
+func (b *beepBackend) OpenPlayback() (io.Writer, error) { return b.audioIO() }
+
+func (b *beepBackend) Info() BackendInfo {
+	sampleRate := b.cfg.SampleRate
+	if sampleRate == 0 {
+		sampleRate = defaultSampleRate
+	}
+	return BackendInfo{Name: "beep", SampleRate: sampleRate, Channels: 1, Latency: b.cfg.Latency}
+}
+
+// Close is a no-op: AudioIO owns the global speaker/microphone streams for
+// the lifetime of the process and has no Close method of its own.
+func (b *beepBackend) Close() error {
+	return nil
+}
@@ -0,0 +1,113 @@
+//go:build !linux || cgo
+
+package audio
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ebitengine/oto/v3"
+	"github.com/smallnest/ringbuffer"
+)
+
+func init() {
+	registerBackend("oto", newOtoBackend)
+}
+
+// otoBackend plays 16-bit PCM through ebitengine/oto. On platforms where oto
+// talks to the OS audio API via purego (Windows, macOS, WASM) this avoids
+// linking libportaudio entirely, including under CGO_ENABLED=0. On Linux,
+// oto itself requires cgo to link ALSA, so this file is excluded from
+// CGO_ENABLED=0 Linux builds (see the build tag above) rather than
+// registering a backend that can never actually open a stream there. The
+// portaudio and beep backends need cgo as well, so a CGO_ENABLED=0 Linux
+// binary has no backend at all; playback there needs CGO_ENABLED=1 (plus
+// libasound2-dev to use oto). It has
+// no capture path of its own; callers that need microphone input should
+// fall back to the portaudio or beep backend for that direction.
+type otoBackend struct {
+	cfg Config
+
+	mu     sync.Mutex
+	ctx    *oto.Context
+	player *oto.Player
+	buf    *ringbuffer.RingBuffer
+}
+
+func newOtoBackend(cfg Config) (Backend, error) {
+	return &otoBackend{cfg: cfg}, nil
+}
+
+func (b *otoBackend) OpenCapture() (io.Reader, error) {
+	return nil, fmt.Errorf("audio: oto backend does not support capture; select the portaudio or beep backend for microphone input")
+}
+
+func (b *otoBackend) OpenPlayback() (io.Writer, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.player != nil {
+		return b, nil
+	}
+
+	sampleRate := b.cfg.SampleRate
+	if sampleRate == 0 {
+		sampleRate = defaultSampleRate
+	}
+	channels := b.cfg.Channels
+	if channels == 0 {
+		channels = 1
+	}
+
+	// Half a second of 16-bit PCM, matching the ~100ms-scale buffers the
+	// other backends use, scaled up since oto pulls from this buffer itself.
+	bufSize := sampleRate * channels * 2 / 2
+	buf := ringbuffer.New(bufSize).SetBlocking(true)
+
+	ctx, ready, err := oto.NewContext(&oto.NewContextOptions{
+		SampleRate:   sampleRate,
+		ChannelCount: channels,
+		Format:       oto.FormatSignedInt16LE,
+		BufferSize:   b.cfg.Latency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("audio: failed to create oto context: %w", err)
+	}
+	<-ready
+
+	player := ctx.NewPlayer(buf)
+	player.Play()
+
+	b.ctx = ctx
+	b.buf = buf
+	b.player = player
+	return b, nil
+}
+
+// Write feeds PCM into the ring buffer the oto player reads from.
+func (b *otoBackend) Write(p []byte) (int, error) {
+	return b.buf.Write(p)
+}
+
+func (b *otoBackend) Info() BackendInfo {
+	sampleRate := b.cfg.SampleRate
+	if sampleRate == 0 {
+		sampleRate = defaultSampleRate
+	}
+	channels := b.cfg.Channels
+	if channels == 0 {
+		channels = 1
+	}
+	return BackendInfo{Name: "oto", SampleRate: sampleRate, Channels: channels, Latency: b.cfg.Latency}
+}
+
+func (b *otoBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.player == nil {
+		return nil
+	}
+	err := b.player.Close()
+	b.buf.CloseWriter()
+	return err
+}
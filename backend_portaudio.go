@@ -0,0 +1,80 @@
+//go:build cgo
+
+package audio
+
+import (
+	"io"
+	"sync"
+)
+
+func init() {
+	registerBackend("portaudio", newPortAudioBackend)
+}
+
+// portAudioBackend adapts PortAudioDevice, a single full-duplex stream, to
+// the Backend interface's separate OpenCapture/OpenPlayback calls.
+type portAudioBackend struct {
+	cfg Config
+
+	mu  sync.Mutex
+	dev *PortAudioDevice
+}
+
+func newPortAudioBackend(cfg Config) (Backend, error) {
+	return &portAudioBackend{cfg: cfg}, nil
+}
+
+func (b *portAudioBackend) device() (*PortAudioDevice, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.dev != nil {
+		return b.dev, nil
+	}
+
+	sampleRate := b.cfg.SampleRate
+	if sampleRate == 0 {
+		sampleRate = defaultSampleRate
+	}
+	channels := b.cfg.Channels
+	if channels == 0 {
+		channels = 1
+	}
+
+	opts := defaultDeviceOptions()
+	if b.cfg.Latency > 0 {
+		opts.InputLatency = b.cfg.Latency
+		opts.OutputLatency = b.cfg.Latency
+	}
+
+	dev, err := NewDeviceWithOptions(sampleRate, channels, opts)
+	if err != nil {
+		return nil, err
+	}
+	b.dev = dev
+	return dev, nil
+}
+
+func (b *portAudioBackend) OpenCapture() (io.Reader, error) { return b.device() }
+
+func (b *portAudioBackend) OpenPlayback() (io.Writer, error) { return b.device() }
+
+func (b *portAudioBackend) Info() BackendInfo {
+	sampleRate := b.cfg.SampleRate
+	if sampleRate == 0 {
+		sampleRate = defaultSampleRate
+	}
+	channels := b.cfg.Channels
+	if channels == 0 {
+		channels = 1
+	}
+	return BackendInfo{Name: "portaudio", SampleRate: sampleRate, Channels: channels, Latency: b.cfg.Latency}
+}
+
+func (b *portAudioBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.dev == nil {
+		return nil
+	}
+	return b.dev.Close()
+}
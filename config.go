@@ -0,0 +1,38 @@
+package audio
+
+import (
+	"time"
+
+	"github.com/codewandler/audio-go/audio/resample"
+)
+
+// Config configures NewAudioIO and the Backend factory (Open). It has no
+// cgo dependency of its own, unlike most of its consumers, so that backends
+// which don't need cgo (e.g. oto on non-Linux platforms) can still be
+// constructed from a CGO_ENABLED=0 build.
+type Config struct {
+	PlaySampleRate    int
+	PlayLatency       time.Duration
+	CaptureSampleRate int
+	CaptureLatency    time.Duration
+
+	// SampleRate, Channels and Latency are generic fields consulted by the
+	// Backend factory (Open) for backends that don't distinguish play/capture
+	// rates. PlaySampleRate/CaptureSampleRate above take priority over
+	// SampleRate when set, so existing NewAudioIO callers are unaffected.
+	SampleRate int
+	Channels   int
+	Latency    time.Duration
+
+	// CaptureFormat and PlayFormat are the PCM formats Read/Write speak. When
+	// they differ from CaptureSampleRate/PlaySampleRate (always mono),
+	// AudioIO resamples and remixes transparently. A zero value means "same
+	// as the capture/play device".
+	CaptureFormat Format
+	PlayFormat    Format
+	// ResampleMethod and ResampleTaps configure the resampler used when
+	// CaptureFormat/PlayFormat require rate conversion; see
+	// resample.Method/resample.New.
+	ResampleMethod resample.Method
+	ResampleTaps   int
+}
@@ -1,12 +1,18 @@
+//go:build cgo
+
 package audio
 
 import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/codewandler/audio-go/audio/resample"
 	"github.com/gordonklaus/portaudio"
 	"github.com/smallnest/ringbuffer"
-	"log/slog"
 )
 
 type PortAudioDevice struct {
@@ -17,9 +23,225 @@ type PortAudioDevice struct {
 	channels        int
 	framesPerBuffer int
 	outputPCM16Buf  []byte
+
+	// ioFormat is the PCM format Read/Write speak, which may differ from the
+	// device's native sampleRate/channels above.
+	ioFormat      Format
+	micResampler  *resample.Resampler // device format -> ioFormat, used by Read
+	playResampler *resample.Resampler // ioFormat -> device format, used by Write
+	micLeftover   []byte              // resampled mic bytes not yet handed to a Read caller
+}
+
+// DeviceInfo describes a single audio device as reported by the host API.
+type DeviceInfo struct {
+	Name                     string
+	HostAPI                  string
+	MaxInputChannels         int
+	MaxOutputChannels        int
+	DefaultSampleRate        float64
+	DefaultLowInputLatency   time.Duration
+	DefaultHighInputLatency  time.Duration
+	DefaultLowOutputLatency  time.Duration
+	DefaultHighOutputLatency time.Duration
+}
+
+// Devices returns information for every audio device visible to PortAudio,
+// so callers can pick an input/output device explicitly instead of relying
+// on the system defaults.
+func Devices() ([]DeviceInfo, error) {
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate devices: %w", err)
+	}
+
+	infos := make([]DeviceInfo, 0, len(devices))
+	for _, d := range devices {
+		hostAPI := ""
+		if d.HostApi != nil {
+			hostAPI = d.HostApi.Name
+		}
+		infos = append(infos, DeviceInfo{
+			Name:                     d.Name,
+			HostAPI:                  hostAPI,
+			MaxInputChannels:         d.MaxInputChannels,
+			MaxOutputChannels:        d.MaxOutputChannels,
+			DefaultSampleRate:        d.DefaultSampleRate,
+			DefaultLowInputLatency:   d.DefaultLowInputLatency,
+			DefaultHighInputLatency:  d.DefaultHighInputLatency,
+			DefaultLowOutputLatency:  d.DefaultLowOutputLatency,
+			DefaultHighOutputLatency: d.DefaultHighOutputLatency,
+		})
+	}
+	return infos, nil
+}
+
+// LatencyPreset selects between the low- and high-latency presets a
+// PortAudio device advertises for itself.
+type LatencyPreset int
+
+const (
+	// LowLatency uses the device's DefaultLow{Input,Output}Latency.
+	LowLatency LatencyPreset = iota
+	// HighLatency uses the device's DefaultHigh{Input,Output}Latency, trading
+	// latency for fewer underruns/overruns on loaded systems.
+	HighLatency
+)
+
+// DeviceOptions configures which input and output devices NewDeviceWithOptions opens
+// and how. InputDevice/OutputDevice and InputDeviceName/OutputDeviceName are
+// both optional; when neither is set the system default device is used.
+// When both are set, the index takes priority. Unlike most options structs
+// in this module, a zero-value DeviceOptions is NOT equivalent to the
+// default: InputDevice/OutputDevice's zero value (0) is a valid device
+// index, so it is picked as an explicit selection rather than as "no
+// selection". Use defaultDeviceOptions (what NewDevice passes) or set
+// InputDevice/OutputDevice to -1 explicitly to get system-default
+// behaviour.
+type DeviceOptions struct {
+	// InputDevice selects the input device by its index into Devices(). A
+	// negative value (what NewDevice uses) means "no explicit selection".
+	InputDevice int
+	// InputDeviceName selects the input device whose Name contains this
+	// substring (case-insensitive). Ignored if InputDevice >= 0.
+	InputDeviceName string
+	// DisableInput skips opening an input stream entirely, for speaker-only
+	// playback devices.
+	DisableInput bool
+
+	// OutputDevice selects the output device by its index into Devices(). A
+	// negative value (what NewDevice uses) means "no explicit selection".
+	OutputDevice int
+	// OutputDeviceName selects the output device whose Name contains this
+	// substring (case-insensitive). Ignored if OutputDevice >= 0.
+	OutputDeviceName string
+	// DisableOutput skips opening an output stream entirely, for mic-only
+	// capture devices.
+	DisableOutput bool
+
+	// Latency picks between the device's low- and high-latency presets.
+	// Defaults to LowLatency.
+	Latency LatencyPreset
+	// InputLatency and OutputLatency override Latency when non-zero.
+	InputLatency  time.Duration
+	OutputLatency time.Duration
+
+	// Format is the PCM format Read/Write will speak. When its SampleRate or
+	// Channels differ from sampleRate/channels passed to
+	// NewDeviceWithOptions, the device resamples and remixes transparently
+	// in both directions. A zero Format means "same as the device".
+	Format Format
+	// ResampleMethod selects the resampling algorithm used when Format
+	// requires rate conversion. Defaults to resample.Linear.
+	ResampleMethod resample.Method
+	// ResampleTaps sets the windowed-sinc kernel half-width used when
+	// ResampleMethod is resample.Sinc. Defaults to 8.
+	ResampleTaps int
+}
+
+// defaultDeviceOptions returns options equivalent to the previous hard-coded
+// default-input/default-output behaviour.
+func defaultDeviceOptions() DeviceOptions {
+	return DeviceOptions{InputDevice: -1, OutputDevice: -1}
+}
+
+func resolveInputDevice(opts DeviceOptions) (*portaudio.DeviceInfo, error) {
+	if opts.DisableInput {
+		return nil, nil
+	}
+	if opts.InputDevice >= 0 {
+		devices, err := portaudio.Devices()
+		if err != nil {
+			return nil, fmt.Errorf("failed to enumerate devices: %w", err)
+		}
+		if opts.InputDevice >= len(devices) {
+			return nil, fmt.Errorf("input device index %d out of range (%d devices)", opts.InputDevice, len(devices))
+		}
+		return devices[opts.InputDevice], nil
+	}
+	if opts.InputDeviceName != "" {
+		return findDeviceByName(opts.InputDeviceName, true)
+	}
+	return portaudio.DefaultInputDevice()
+}
+
+func resolveOutputDevice(opts DeviceOptions) (*portaudio.DeviceInfo, error) {
+	if opts.DisableOutput {
+		return nil, nil
+	}
+	if opts.OutputDevice >= 0 {
+		devices, err := portaudio.Devices()
+		if err != nil {
+			return nil, fmt.Errorf("failed to enumerate devices: %w", err)
+		}
+		if opts.OutputDevice >= len(devices) {
+			return nil, fmt.Errorf("output device index %d out of range (%d devices)", opts.OutputDevice, len(devices))
+		}
+		return devices[opts.OutputDevice], nil
+	}
+	if opts.OutputDeviceName != "" {
+		return findDeviceByName(opts.OutputDeviceName, false)
+	}
+	return portaudio.DefaultOutputDevice()
+}
+
+// findDeviceByName returns the first device whose name contains substr
+// (case-insensitive) that supports the requested direction.
+func findDeviceByName(substr string, input bool) (*portaudio.DeviceInfo, error) {
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate devices: %w", err)
+	}
+	substr = strings.ToLower(substr)
+	for _, d := range devices {
+		if !strings.Contains(strings.ToLower(d.Name), substr) {
+			continue
+		}
+		if input && d.MaxInputChannels > 0 {
+			return d, nil
+		}
+		if !input && d.MaxOutputChannels > 0 {
+			return d, nil
+		}
+	}
+	direction := "output"
+	if input {
+		direction = "input"
+	}
+	return nil, fmt.Errorf("no %s device matching %q found", direction, substr)
+}
+
+func inputLatency(dev *portaudio.DeviceInfo, opts DeviceOptions) time.Duration {
+	if opts.InputLatency > 0 {
+		return opts.InputLatency
+	}
+	if opts.Latency == HighLatency {
+		return dev.DefaultHighInputLatency
+	}
+	return dev.DefaultLowInputLatency
+}
+
+func outputLatency(dev *portaudio.DeviceInfo, opts DeviceOptions) time.Duration {
+	if opts.OutputLatency > 0 {
+		return opts.OutputLatency
+	}
+	if opts.Latency == HighLatency {
+		return dev.DefaultHighOutputLatency
+	}
+	return dev.DefaultLowOutputLatency
 }
 
+// NewDevice opens the system default input and output devices for
+// simultaneous capture/playback. Use NewDeviceWithOptions to select specific
+// devices.
 func NewDevice(sampleRate, channels int) (*PortAudioDevice, error) {
+	return NewDeviceWithOptions(sampleRate, channels, defaultDeviceOptions())
+}
+
+// NewDeviceWithOptions opens a PortAudioDevice using explicitly selected
+// input/output devices, latency presets, and optionally a mic-only or
+// speaker-only (asymmetric) stream. Pass a zero-value DeviceOptions'
+// InputDevice/OutputDevice as -1 to keep using the system defaults.
+func NewDeviceWithOptions(sampleRate, channels int, opts DeviceOptions) (*PortAudioDevice, error) {
 	fpb := 512
 	bufSize := int(float64(sampleRate) * 2.0 * 0.1)
 	device := &PortAudioDevice{
@@ -31,50 +253,59 @@ func NewDevice(sampleRate, channels int) (*PortAudioDevice, error) {
 		outputPCM16Buf:  make([]byte, fpb*channels*2),
 	}
 
-	// Get default devices
-	inputDevice, err := portaudio.DefaultInputDevice()
+	inputDevice, err := resolveInputDevice(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default input device: %w", err)
+		return nil, fmt.Errorf("failed to resolve input device: %w", err)
 	}
 
-	outputDevice, err := portaudio.DefaultOutputDevice()
+	outputDevice, err := resolveOutputDevice(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default output device: %w", err)
+		return nil, fmt.Errorf("failed to resolve output device: %w", err)
 	}
 
-	inputParams := portaudio.StreamParameters{
-		Input: portaudio.StreamDeviceParameters{
+	if inputDevice == nil && outputDevice == nil {
+		return nil, fmt.Errorf("at least one of input or output device must be enabled")
+	}
+
+	var streamParams portaudio.StreamParameters
+	streamParams.SampleRate = float64(sampleRate)
+	streamParams.FramesPerBuffer = device.framesPerBuffer
+
+	if inputDevice != nil {
+		streamParams.Input = portaudio.StreamDeviceParameters{
 			Device:   inputDevice,
 			Channels: channels,
-			Latency:  inputDevice.DefaultLowInputLatency,
-		},
-		SampleRate:      float64(sampleRate),
-		FramesPerBuffer: device.framesPerBuffer,
+			Latency:  inputLatency(inputDevice, opts),
+		}
 	}
-
-	outputParams := portaudio.StreamParameters{
-		Output: portaudio.StreamDeviceParameters{
+	if outputDevice != nil {
+		streamParams.Output = portaudio.StreamDeviceParameters{
 			Device:   outputDevice,
 			Channels: channels,
-			Latency:  outputDevice.DefaultLowOutputLatency,
-		},
-		SampleRate:      float64(sampleRate),
-		FramesPerBuffer: device.framesPerBuffer,
+			Latency:  outputLatency(outputDevice, opts),
+		}
 	}
 
-	stream, err := portaudio.OpenStream(portaudio.StreamParameters{
-		Input:           inputParams.Input,
-		Output:          outputParams.Output,
-		SampleRate:      float64(sampleRate),
-		FramesPerBuffer: device.framesPerBuffer,
-	}, device.processAudio)
-
+	stream, err := portaudio.OpenStream(streamParams, device.processAudio)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create audio stream: %w", err)
 	}
 
 	device.stream = stream
 
+	deviceFormat := Format{SampleRate: sampleRate, Channels: channels, Encoding: EncodingPCM16}
+	device.ioFormat = opts.Format.orDefault(deviceFormat)
+	if device.ioFormat != deviceFormat {
+		device.micResampler = resample.New(
+			resample.Format{SampleRate: deviceFormat.SampleRate, Channels: deviceFormat.Channels},
+			resample.Format{SampleRate: device.ioFormat.SampleRate, Channels: device.ioFormat.Channels},
+			opts.ResampleMethod, opts.ResampleTaps)
+		device.playResampler = resample.New(
+			resample.Format{SampleRate: device.ioFormat.SampleRate, Channels: device.ioFormat.Channels},
+			resample.Format{SampleRate: deviceFormat.SampleRate, Channels: deviceFormat.Channels},
+			opts.ResampleMethod, opts.ResampleTaps)
+	}
+
 	if err := stream.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start audio stream: %w", err)
 	}
@@ -123,12 +354,39 @@ func (d *PortAudioDevice) processAudio(input, output []float32) {
 	}
 }
 
+// Read returns captured PCM in d.ioFormat, resampling from the device's
+// native format if NewDeviceWithOptions was given a different Format.
 func (d *PortAudioDevice) Read(p []byte) (n int, err error) {
-	return d.micBuffer.Read(p)
+	if d.micResampler == nil {
+		return d.micBuffer.Read(p)
+	}
+
+	for len(d.micLeftover) == 0 {
+		raw := make([]byte, d.framesPerBuffer*d.channels*2)
+		n, err := d.micBuffer.Read(raw)
+		if n == 0 {
+			return 0, err
+		}
+		d.micLeftover = int16ToBytes(d.micResampler.Process(bytesToInt16(raw[:n])))
+	}
+
+	n = copy(p, d.micLeftover)
+	d.micLeftover = d.micLeftover[n:]
+	return n, nil
 }
 
+// Write accepts PCM in d.ioFormat, resampling to the device's native format
+// if NewDeviceWithOptions was given a different Format.
 func (d *PortAudioDevice) Write(p []byte) (n int, err error) {
-	return d.playbackBuffer.Write(p)
+	if d.playResampler == nil {
+		return d.playbackBuffer.Write(p)
+	}
+
+	converted := int16ToBytes(d.playResampler.Process(bytesToInt16(p)))
+	if _, err := d.playbackBuffer.Write(converted); err != nil {
+		return 0, err
+	}
+	return len(p), nil
 }
 
 func (d *PortAudioDevice) Close() error {
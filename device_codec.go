@@ -0,0 +1,143 @@
+//go:build cgo
+
+package audio
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codewandler/audio-go/audio/codec"
+	"github.com/codewandler/audio-go/audio/resample"
+)
+
+// Record captures audio from the device and encodes it to path, picking the
+// codec from path's extension. Only .wav and .opus support encoding in this
+// module's codec package; .mp3 and .flac are decode-only (see audio/codec),
+// so Record rejects those extensions up front with a clear error rather
+// than the registry's generic "no encoder" miss. It blocks until the device
+// is closed or an error occurs, mirroring the io.Copy(dev, dev) pattern the
+// rest of this package uses.
+func (d *PortAudioDevice) Record(path string) error {
+	name, err := codecNameFromPath(path)
+	if err != nil {
+		return err
+	}
+	if name == "mp3" || name == "flac" {
+		return fmt.Errorf("audio: %s is decode-only in this module (no pure-Go/cgo-free encoder available); use .wav or .opus to record", name)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	enc, err := codec.NewEncoder(f, codec.Format{SampleRate: d.ioFormat.SampleRate, Channels: d.ioFormat.Channels}, name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s encoder: %w", name, err)
+	}
+
+	_, copyErr := io.Copy(enc, d)
+	if closeErr := enc.Close(); closeErr != nil && copyErr == nil {
+		copyErr = closeErr
+	}
+	return copyErr
+}
+
+// Play decodes path and writes the result to the device, resampling on the
+// fly if the file's format doesn't match the device's. The codec is picked
+// from path's extension (.wav, .mp3, .flac, .opus).
+//
+// Unlike the other codecs, an Opus stream carries no sample-rate/
+// channel-count header of its own (see codec.NewOpusDecoder), so an .opus
+// file is assumed to have been encoded at the device's current format, as a
+// prior Record call to the same device would have done; there is no way to
+// recover the original format from the stream itself.
+func (d *PortAudioDevice) Play(path string) error {
+	name, err := codecNameFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var dec io.Reader
+	var format codec.Format
+	if name == "opus" {
+		format = codec.Format{SampleRate: d.ioFormat.SampleRate, Channels: d.ioFormat.Channels}
+		dec, err = codec.NewOpusDecoder(f, format)
+	} else {
+		dec, format, err = codec.NewDecoder(f, name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %s decoder: %w", name, err)
+	}
+
+	if format.SampleRate != d.ioFormat.SampleRate || format.Channels != d.ioFormat.Channels {
+		dec = newResamplingReader(dec,
+			resample.Format{SampleRate: format.SampleRate, Channels: format.Channels},
+			resample.Format{SampleRate: d.ioFormat.SampleRate, Channels: d.ioFormat.Channels})
+	}
+
+	_, err = io.Copy(d, dec)
+	return err
+}
+
+func codecNameFromPath(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".wav":
+		return "wav", nil
+	case ".mp3":
+		return "mp3", nil
+	case ".flac":
+		return "flac", nil
+	case ".opus":
+		return "opus", nil
+	default:
+		return "", fmt.Errorf("audio: no codec registered for extension %q", ext)
+	}
+}
+
+// resamplingReader adapts an io.Reader of PCM16 at 'from' into one at 'to',
+// buffering the tail of each converted chunk that doesn't evenly fill the
+// caller's buffer.
+type resamplingReader struct {
+	r         io.Reader
+	resampler *resample.Resampler
+	chunk     []byte
+	pending   []byte
+}
+
+func newResamplingReader(r io.Reader, from, to resample.Format) *resamplingReader {
+	return &resamplingReader{
+		r:         r,
+		resampler: resample.New(from, to, resample.Linear, 0),
+		chunk:     make([]byte, 4096),
+	}
+}
+
+func (r *resamplingReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		n, err := r.r.Read(r.chunk)
+		if n > 0 {
+			r.pending = int16ToBytes(r.resampler.Process(bytesToInt16(r.chunk[:n])))
+		}
+		if n == 0 && err != nil {
+			return 0, err
+		}
+		if err != nil && len(r.pending) == 0 {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
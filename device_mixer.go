@@ -0,0 +1,48 @@
+//go:build cgo
+
+package audio
+
+import (
+	"io"
+
+	"github.com/codewandler/audio-go/audio/mixer"
+	"github.com/codewandler/audio-go/audio/resample"
+)
+
+// PlayMixer continuously reads m's mixed output and writes it to the
+// device's playback path, remixing from stereo (the layout audio/mixer
+// always produces) to the device's ioFormat channel count if they differ.
+// The mixer itself performs no resampling, so its voices must already be at
+// d's sample rate; use the audio/resample package on a voice's Reader first
+// if it isn't.
+//
+// PlayMixer blocks until stop is closed or a Write to the device fails,
+// so it's meant to be started in its own goroutine: go device.PlayMixer(m,
+// stop).
+func (d *PortAudioDevice) PlayMixer(m *mixer.Mixer, stop <-chan struct{}) error {
+	var r io.Reader = m
+	mixerFormat := resample.Format{SampleRate: d.ioFormat.SampleRate, Channels: 2}
+	deviceFormat := resample.Format{SampleRate: d.ioFormat.SampleRate, Channels: d.ioFormat.Channels}
+	if mixerFormat != deviceFormat {
+		r = newResamplingReader(m, mixerFormat, deviceFormat)
+	}
+
+	buf := make([]byte, d.framesPerBuffer*d.channels*2)
+	for {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, werr := d.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
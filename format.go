@@ -0,0 +1,55 @@
+package audio
+
+import "encoding/binary"
+
+// Encoding identifies the sample encoding of a Format. PCM16 (16-bit signed
+// little-endian PCM) is the only encoding this module moves end-to-end;
+// it's called out explicitly so codecs and future formats have somewhere to
+// register themselves.
+type Encoding int
+
+const (
+	// EncodingPCM16 is 16-bit signed little-endian PCM.
+	EncodingPCM16 Encoding = iota
+)
+
+// Format describes the PCM layout a caller reads from or writes to a
+// device/backend, which may differ from the layout the underlying hardware
+// actually captures/plays at. When the two differ, the device resamples and
+// remixes channels transparently (see the audio/resample subpackage).
+type Format struct {
+	SampleRate int
+	Channels   int
+	Encoding   Encoding
+}
+
+// orDefault fills zero fields of f from def, leaving explicitly set fields
+// untouched.
+func (f Format) orDefault(def Format) Format {
+	if f.SampleRate == 0 {
+		f.SampleRate = def.SampleRate
+	}
+	if f.Channels == 0 {
+		f.Channels = def.Channels
+	}
+	return f
+}
+
+// bytesToInt16 reinterprets little-endian PCM16 bytes as samples, discarding
+// a trailing odd byte if present.
+func bytesToInt16(b []byte) []int16 {
+	out := make([]int16, len(b)/2)
+	for i := range out {
+		out[i] = int16(binary.LittleEndian.Uint16(b[i*2:]))
+	}
+	return out
+}
+
+// int16ToBytes encodes samples as little-endian PCM16 bytes.
+func int16ToBytes(samples []int16) []byte {
+	out := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(s))
+	}
+	return out
+}